@@ -1,12 +1,12 @@
 package test
 
 import (
-	"io/ioutil"
-	"net/http"
 	"regexp"
 	"strings"
 
+	ftcontracts "github.com/onflow/flow-ft/lib/go/contracts"
 	"github.com/onflow/flow-go-sdk"
+	nftcontracts "github.com/onflow/flow-nft/lib/go/contracts"
 )
 
 // Handle relative paths by making these regular expressions
@@ -38,11 +38,6 @@ const (
 	ReadNftSupplyScriptPath = ScriptsRootPath + "/total_supply.cdc"
 	ReadNftPropertiesTxPath = ScriptsRootPath + "/get_nft.cdc"
 
-	// MetadataViews
-	MetadataViewsContractsBaseURL = "https://raw.githubusercontent.com/onflow/flow-nft/master/contracts/"
-	MetadataViewsInterfaceFile    = "MetadataViews.cdc"
-	MetadataFTReplaceAddress      = `"./utility/FungibleToken.cdc"`
-	MetadataNFTReplaceAddress     = `"./NonFungibleToken.cdc"`
 )
 
 // ------------------------------------------------------------
@@ -142,20 +137,36 @@ func readNFTPropertiesScript(contracts Contracts) []byte {
 	)
 }
 
-func DownloadFile(url string) ([]byte, error) {
-	// Get the data
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	return ioutil.ReadAll(resp.Body)
+// StandardContracts vendors the shared Flow contracts tests deploy alongside DSSCollection,
+// reading their source from the already-imported flow-nft / flow-ft Go modules instead of
+// downloading them from GitHub on every run. Which version of those sources gets loaded is
+// pinned the normal Go way, via the flow-nft/flow-ft requirements in go.mod - bump those
+// deliberately, not as a side effect of `go get -u`, since a new flow-nft release can change
+// Cadence syntax tests depend on.
+type StandardContracts struct {
+	FungibleToken    []byte
+	NonFungibleToken []byte
+	ViewResolver     []byte
+	MetadataViews    []byte
 }
 
-func LoadMetadataViews(ftAddress flow.Address, nftAddress flow.Address) []byte {
-	code, _ := DownloadFile(MetadataViewsContractsBaseURL + MetadataViewsInterfaceFile)
-	code = []byte(strings.Replace(strings.Replace(string(code), MetadataFTReplaceAddress, "0x"+ftAddress.String(), 1), MetadataNFTReplaceAddress, "0x"+nftAddress.String(), 1))
+// LoadStandardContracts returns the FungibleToken, NonFungibleToken, ViewResolver and
+// MetadataViews sources with viewResolverAddress, ftAddress and nftAddress substituted in, so
+// tests no longer need to hit raw.githubusercontent.com to get a MetadataViews implementation.
+// viewResolverAddress is the account ViewResolver is deployed to, which the ViewResolver-era
+// MetadataViews contract imports alongside FungibleToken and NonFungibleToken.
+func LoadStandardContracts(viewResolverAddress flow.Address, ftAddress flow.Address, nftAddress flow.Address) StandardContracts {
+	return StandardContracts{
+		FungibleToken:    ftcontracts.FungibleToken(),
+		NonFungibleToken: nftcontracts.NonFungibleToken(),
+		ViewResolver:     nftcontracts.ViewResolver(),
+		MetadataViews:    nftcontracts.MetadataViews(viewResolverAddress, ftAddress, nftAddress),
+	}
+}
 
-	return code
+// LoadMetadataViews returns the MetadataViews contract source with viewResolverAddress,
+// ftAddress and nftAddress substituted in. Kept as a thin wrapper around LoadStandardContracts
+// for callers that only need the one contract.
+func LoadMetadataViews(viewResolverAddress flow.Address, ftAddress flow.Address, nftAddress flow.Address) []byte {
+	return LoadStandardContracts(viewResolverAddress, ftAddress, nftAddress).MetadataViews
 }
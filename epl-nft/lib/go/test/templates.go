@@ -0,0 +1,69 @@
+package test
+
+import (
+	"regexp"
+	"strings"
+
+	ftcontracts "github.com/onflow/flow-ft/lib/go/contracts"
+	"github.com/onflow/flow-go-sdk"
+	nftcontracts "github.com/onflow/flow-nft/lib/go/contracts"
+)
+
+// Handle relative paths by making these regular expressions
+
+const (
+	nftAddressPlaceholder           = "\"[^\"]*NonFungibleToken.cdc\""
+	EPLAddressPlaceholder           = "\"[^\"]*EnglishPremierLeague.cdc\""
+	metadataViewsAddressPlaceholder = "0xMETADATAVIEWSADDRESS"
+)
+
+const (
+	TransactionsRootPath = "../../../transactions"
+	ScriptsRootPath      = "../../../scripts"
+)
+
+func replaceAddresses(code []byte, contracts Contracts) []byte {
+	nftRe := regexp.MustCompile(nftAddressPlaceholder)
+	code = nftRe.ReplaceAll(code, []byte("0x"+contracts.NFTAddress.String()))
+
+	eplRe := regexp.MustCompile(EPLAddressPlaceholder)
+	code = eplRe.ReplaceAll(code, []byte("0x"+contracts.EPLAddress.String()))
+
+	code = []byte(strings.ReplaceAll(string(code), metadataViewsAddressPlaceholder, "0x"+contracts.MetadataViewsAddress.String()))
+
+	return code
+}
+
+// StandardContracts vendors the shared Flow contracts tests deploy alongside EnglishPremierLeague,
+// reading their source from the already-imported flow-nft / flow-ft Go modules instead of
+// downloading them from GitHub on every run. Which version of those sources gets loaded is
+// pinned the normal Go way, via the flow-nft/flow-ft requirements in go.mod - bump those
+// deliberately, not as a side effect of `go get -u`, since a new flow-nft release can change
+// Cadence syntax tests depend on.
+type StandardContracts struct {
+	FungibleToken    []byte
+	NonFungibleToken []byte
+	ViewResolver     []byte
+	MetadataViews    []byte
+}
+
+// LoadStandardContracts returns the FungibleToken, NonFungibleToken, ViewResolver and
+// MetadataViews sources with viewResolverAddress, ftAddress and nftAddress substituted in, so
+// tests no longer need to hit raw.githubusercontent.com to get a MetadataViews implementation.
+// viewResolverAddress is the account ViewResolver is deployed to, which the ViewResolver-era
+// MetadataViews contract imports alongside FungibleToken and NonFungibleToken.
+func LoadStandardContracts(viewResolverAddress flow.Address, ftAddress flow.Address, nftAddress flow.Address) StandardContracts {
+	return StandardContracts{
+		FungibleToken:    ftcontracts.FungibleToken(),
+		NonFungibleToken: nftcontracts.NonFungibleToken(),
+		ViewResolver:     nftcontracts.ViewResolver(),
+		MetadataViews:    nftcontracts.MetadataViews(viewResolverAddress, ftAddress, nftAddress),
+	}
+}
+
+// LoadMetadataViews returns the MetadataViews contract source with viewResolverAddress,
+// ftAddress and nftAddress substituted in. Kept as a thin wrapper around LoadStandardContracts
+// for callers that only need the one contract.
+func LoadMetadataViews(viewResolverAddress flow.Address, ftAddress flow.Address, nftAddress flow.Address) []byte {
+	return LoadStandardContracts(viewResolverAddress, ftAddress, nftAddress).MetadataViews
+}
@@ -58,7 +58,18 @@ func EPLDeployContracts(t *testing.T, b *emulator.Blockchain) Contracts {
 	accountKeys := test.AccountKeyGenerator()
 
 	nftAddress := deployNFTContract(t, b)
-	metadataCode := LoadMetadataViews(ftAddress, nftAddress)
+
+	viewResolverAddr, err := b.CreateAccount(nil, []sdktemplates.Contract{
+		{
+			Name:   "ViewResolver",
+			Source: string(nftcontracts.ViewResolver()),
+		},
+	})
+	require.NoError(t, err)
+	_, err = b.CommitBlock()
+	require.NoError(t, err)
+
+	metadataCode := LoadMetadataViews(viewResolverAddr, ftAddress, nftAddress)
 	metadataViewsAddr, err := b.CreateAccount(nil, []sdktemplates.Contract{
 		{
 			Name:   "MetadataViews",
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// This fixture mirrors the JSON Flow CLI actually emits for `--cover --coverprofile=<file>.json`:
+// the Cadence runtime coverage report's LocationCoverage per contract, keyed by location,
+// with "statements" and the lines that were *not* hit rather than a direct "covered" count.
+const coverageFixture = `{
+	"coverage": {
+		"A.0000000000000001.FooContract": {
+			"statements": 10,
+			"missed_lines": [4, 7]
+		},
+		"A.0000000000000001.BarContract": {
+			"statements": 5,
+			"missed_lines": []
+		}
+	}
+}`
+
+func TestParseCoverageJSON(t *testing.T) {
+	report, err := parseCoverageJSON([]byte(coverageFixture))
+	require.NoError(t, err)
+
+	require.Contains(t, report.Contracts, "A.0000000000000001.FooContract")
+	foo := report.Contracts["A.0000000000000001.FooContract"]
+	assert.Equal(t, 10, foo.Statements)
+	assert.Equal(t, 8, foo.Covered)
+
+	require.Contains(t, report.Contracts, "A.0000000000000001.BarContract")
+	bar := report.Contracts["A.0000000000000001.BarContract"]
+	assert.Equal(t, 5, bar.Statements)
+	assert.Equal(t, 5, bar.Covered)
+
+	report.recompute()
+	assert.Equal(t, 15, report.Statements)
+	assert.Equal(t, 13, report.Covered)
+	assert.InDelta(t, 86.67, report.Percentage, 0.01)
+}
+
+func TestParseCoverageJSON_Invalid(t *testing.T) {
+	_, err := parseCoverageJSON([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,221 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	emulator "github.com/onflow/flow-emulator"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	sdktemplates "github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/stretchr/testify/require"
+)
+
+// ContractSpec describes one contract in a Deployer's dependency graph: its name, Cadence
+// source importing its dependencies in either the legacy relative-path form
+// (`import Foo from "../Foo.cdc"`) or Cadence 1.0's named form (`import Foo from "Foo"`) - the
+// forms ImportResolver.Resolve rewrites - the arguments its `init` takes, and the names of
+// contracts (from the same Deployer run) it imports and must therefore be deployed after.
+type ContractSpec struct {
+	Name      string
+	Source    []byte
+	Args      []cadence.Value
+	DependsOn []string
+}
+
+// DeployedContracts maps contract name to the address it was deployed to, returned by a
+// Deployer run so tests don't have to thread individual address variables through.
+type DeployedContracts map[string]flow.Address
+
+// Deployer computes a dependency DAG across a set of contracts, deploying each one in
+// topological order and substituting the addresses of its already-deployed dependencies into
+// its source, instead of every project hand-rolling sdktemplates.AddAccountContract and signing
+// for one hard-coded contract set. It does not fund the accounts it creates.
+type Deployer struct {
+	t              *testing.T
+	b              *emulator.Blockchain
+	serviceAddress flow.Address
+	serviceSigner  crypto.Signer
+	deployed       DeployedContracts
+	signers        map[string]crypto.Signer
+}
+
+// NewDeployer returns a Deployer that deploys contracts to b using b's own service account as
+// the default payer/proposer, overridable via WithServiceAccount.
+func NewDeployer(t *testing.T, b *emulator.Blockchain) *Deployer {
+	signer, err := b.ServiceKey().Signer()
+	require.NoError(t, err)
+
+	return &Deployer{
+		t:              t,
+		b:              b,
+		serviceAddress: b.ServiceKey().Address,
+		serviceSigner:  signer,
+		deployed:       DeployedContracts{},
+		signers:        map[string]crypto.Signer{},
+	}
+}
+
+// WithServiceAccount overrides the account that pays for and proposes each deployment
+// transaction. Returns the Deployer so calls can be chained.
+func (d *Deployer) WithServiceAccount(address flow.Address, signer crypto.Signer) *Deployer {
+	d.serviceAddress = address
+	d.serviceSigner = signer
+	return d
+}
+
+// Deploy deploys a single contract spec to a fresh account, substituting the addresses of any
+// already-deployed dependencies into its source first and passing spec.Args to the contract's
+// `init`. It requires every name in spec.DependsOn to have already been deployed by this
+// Deployer. Returns the Deployer so calls can be chained:
+// NewDeployer(t, b).Deploy(a).Deploy(b).Deployed().
+func (d *Deployer) Deploy(spec ContractSpec) *Deployer {
+	d.t.Helper()
+
+	source := d.resolveDependencies(spec)
+
+	accountKeys := test.AccountKeyGenerator()
+	accountKey, accountSigner := accountKeys.NewWithSigner()
+
+	address, err := d.b.CreateAccount([]*flow.AccountKey{accountKey}, nil)
+	require.NoError(d.t, err)
+	_, err = d.b.CommitBlock()
+	require.NoError(d.t, err)
+
+	proposer, err := d.b.GetAccount(d.serviceAddress)
+	require.NoError(d.t, err)
+
+	tx := sdktemplates.AddAccountContract(
+		address,
+		sdktemplates.Contract{Name: spec.Name, Source: string(source)},
+	).
+		SetGasLimit(100).
+		SetProposalKey(d.serviceAddress, proposer.Keys[0].Index, proposer.Keys[0].SequenceNumber).
+		SetPayer(d.serviceAddress).
+		AddAuthorizer(address)
+
+	for _, arg := range spec.Args {
+		require.NoError(d.t, tx.AddArgument(arg))
+	}
+
+	result := signAndSubmit(
+		d.t, d.b, tx,
+		[]flow.Address{d.serviceAddress, address},
+		[]crypto.Signer{d.serviceSigner, accountSigner},
+		false,
+	)
+
+	d.t.Logf("deployer: deployed %s to 0x%s (tx %s, computation used %d)", spec.Name, address.String(), result.TransactionID.String(), result.ComputationUsed)
+
+	d.deployed[spec.Name] = address
+	d.signers[spec.Name] = accountSigner
+	return d
+}
+
+// DeployAll deploys every spec in dependency order, regardless of the order they're passed in,
+// so a project can declare its contract set once as a manifest instead of manually sequencing
+// each deploy.
+func (d *Deployer) DeployAll(specs []ContractSpec) *Deployer {
+	d.t.Helper()
+
+	for _, spec := range sortByDependencies(d.t, specs) {
+		d.Deploy(spec)
+	}
+	return d
+}
+
+// Upgrade runs an UpdateAccountContract transaction against a previously deployed contract, for
+// tests that exercise migrations rather than a fresh deploy.
+func (d *Deployer) Upgrade(spec ContractSpec) *Deployer {
+	d.t.Helper()
+
+	address, ok := d.deployed[spec.Name]
+	require.True(d.t, ok, "cannot upgrade %s: it was not deployed by this Deployer", spec.Name)
+	accountSigner := d.signers[spec.Name]
+
+	source := d.resolveDependencies(spec)
+
+	proposer, err := d.b.GetAccount(d.serviceAddress)
+	require.NoError(d.t, err)
+
+	tx := sdktemplates.UpdateAccountContract(
+		address,
+		sdktemplates.Contract{Name: spec.Name, Source: string(source)},
+	).
+		SetGasLimit(100).
+		SetProposalKey(d.serviceAddress, proposer.Keys[0].Index, proposer.Keys[0].SequenceNumber).
+		SetPayer(d.serviceAddress).
+		AddAuthorizer(address)
+
+	result := signAndSubmit(
+		d.t, d.b, tx,
+		[]flow.Address{d.serviceAddress, address},
+		[]crypto.Signer{d.serviceSigner, accountSigner},
+		false,
+	)
+
+	d.t.Logf("deployer: upgraded %s at 0x%s (tx %s, computation used %d)", spec.Name, address.String(), result.TransactionID.String(), result.ComputationUsed)
+
+	return d
+}
+
+// Deployed returns the address every contract deployed so far was assigned.
+func (d *Deployer) Deployed() DeployedContracts {
+	return d.deployed
+}
+
+// resolveDependencies rewrites each `import <Name> from "..."` in spec.Source - legacy
+// relative-path or Cadence 1.0 named form - to the real address of each already-deployed
+// dependency, via the same ImportResolver used for flow.json-driven manifests.
+func (d *Deployer) resolveDependencies(spec ContractSpec) []byte {
+	source := spec.Source
+	for _, name := range spec.DependsOn {
+		address, ok := d.deployed[name]
+		require.True(d.t, ok, "cannot deploy %s: dependency %s has not been deployed yet", spec.Name, name)
+
+		resolver := &ImportResolver{addresses: map[string]flow.Address{name: address}}
+		source = resolver.Resolve(source)
+	}
+	return source
+}
+
+// sortByDependencies topologically sorts specs so that every contract comes after everything
+// it depends on, and fails the test on a dependency cycle.
+func sortByDependencies(t *testing.T, specs []ContractSpec) []ContractSpec {
+	t.Helper()
+
+	byName := make(map[string]ContractSpec, len(specs))
+	for _, spec := range specs {
+		byName[spec.Name] = spec
+	}
+
+	var sorted []ContractSpec
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		require.False(t, visiting[name], "dependency cycle detected at contract %s", name)
+		visiting[name] = true
+
+		spec, ok := byName[name]
+		require.True(t, ok, "unknown dependency %s", name)
+		for _, dep := range spec.DependsOn {
+			visit(dep)
+		}
+
+		visiting[name] = false
+		visited[name] = true
+		sorted = append(sorted, spec)
+	}
+
+	for _, spec := range specs {
+		visit(spec.Name)
+	}
+
+	return sorted
+}
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// legacyImportRe matches Cadence's pre-1.0 relative-path import form, e.g.
+// `import NonFungibleToken from "../../contracts/NonFungibleToken.cdc"`.
+var legacyImportRe = regexp.MustCompile(`import\s+(\w+)\s+from\s+"[^"]*/(\w+)\.cdc"`)
+
+// namedImportRe matches Cadence 1.0's string-import form, e.g.
+// `import NonFungibleToken from "NonFungibleToken"`.
+var namedImportRe = regexp.MustCompile(`import\s+(\w+)\s+from\s+"(\w+)"`)
+
+// ContractConfig is one entry under flow.json's "contracts" section. It accepts both the short
+// form, `"Name": "./path/to/Name.cdc"`, and the long form with per-network address aliases,
+// `"Name": {"source": "./path/to/Name.cdc", "aliases": {"testnet": "0x..."}}`.
+type ContractConfig struct {
+	Source  string
+	Aliases map[string]flow.Address
+}
+
+func (c *ContractConfig) UnmarshalJSON(data []byte) error {
+	var source string
+	if err := json.Unmarshal(data, &source); err == nil {
+		c.Source = source
+		return nil
+	}
+
+	var long struct {
+		Source  string                  `json:"source"`
+		Aliases map[string]flow.Address `json:"aliases,omitempty"`
+	}
+	if err := json.Unmarshal(data, &long); err != nil {
+		return err
+	}
+
+	c.Source = long.Source
+	c.Aliases = long.Aliases
+	return nil
+}
+
+// FlowConfig is the subset of flow.json this package understands: the contracts a project
+// declares, which accounts they're deployed to, and any address aliases for contracts that
+// already live on a known network (e.g. NonFungibleToken on testnet/mainnet).
+type FlowConfig struct {
+	Contracts   map[string]ContractConfig      `json:"contracts"`
+	Deployments map[string]map[string][]string `json:"deployments"`
+}
+
+// ImportResolver rewrites both the legacy relative-path import form and Cadence 1.0's named
+// import form using a single manifest, and returns addresses keyed by contract name rather
+// than through fixed struct fields (NFTAddress, DSSCollectionAddress, ...) that need editing
+// every time a project adds a contract.
+type ImportResolver struct {
+	network     string
+	addresses   map[string]flow.Address
+	deployments map[string]map[string][]string
+}
+
+// NewImportResolver parses a flow.json at path and builds a resolver that rewrites imports
+// using each contract's alias for network (e.g. "emulator", "testnet", "mainnet"); network's
+// deployments are only kept for ExpectedContracts. Call Bind to fill in addresses for contracts
+// deployed during a test run.
+func NewImportResolver(path string, network string) (*ImportResolver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading flow.json: %w", err)
+	}
+
+	var config FlowConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing flow.json: %w", err)
+	}
+
+	return NewImportResolverFromConfig(config, network), nil
+}
+
+// NewImportResolverFromConfig builds a resolver from an in-memory FlowConfig, for tests that
+// don't want to round-trip through a file on disk. Only each contract's alias for network is
+// loaded, so resolving the same manifest always picks the same address rather than depending on
+// Go's randomized map iteration order across a contract's aliases.
+func NewImportResolverFromConfig(config FlowConfig, network string) *ImportResolver {
+	addresses := map[string]flow.Address{}
+	for name, contract := range config.Contracts {
+		if alias, ok := contract.Aliases[network]; ok {
+			addresses[name] = alias
+		}
+	}
+
+	return &ImportResolver{
+		network:     network,
+		addresses:   addresses,
+		deployments: config.Deployments,
+	}
+}
+
+// Bind records the address a contract was deployed to during this test run, so subsequent
+// calls to Resolve can rewrite imports of it.
+func (r *ImportResolver) Bind(contractName string, address flow.Address) {
+	r.addresses[contractName] = address
+}
+
+// Contracts returns every contract name this resolver currently knows an address for.
+func (r *ImportResolver) Contracts() map[string]flow.Address {
+	out := make(map[string]flow.Address, len(r.addresses))
+	for name, addr := range r.addresses {
+		out[name] = addr
+	}
+	return out
+}
+
+// ExpectedContracts returns every contract name flow.json's "deployments" section declares for
+// r's network, across every account, so a test can assert its Deployer run covered the whole
+// manifest instead of silently skipping an entry.
+func (r *ImportResolver) ExpectedContracts() []string {
+	var names []string
+	for _, contracts := range r.deployments[r.network] {
+		names = append(names, contracts...)
+	}
+	return names
+}
+
+// Resolve rewrites every import statement in code - whether it uses the legacy relative-path
+// form or Cadence 1.0's named-import form - to an address import, using the contract name to
+// look up the address rather than a fixed set of regex placeholders. Imports of contracts the
+// resolver doesn't know about are left untouched.
+func (r *ImportResolver) Resolve(code []byte) []byte {
+	rewrite := func(match string, re *regexp.Regexp) string {
+		groups := re.FindStringSubmatch(match)
+		identifier, contractName := groups[1], groups[2]
+
+		address, ok := r.addresses[contractName]
+		if !ok {
+			return match
+		}
+
+		return fmt.Sprintf(`import %s from 0x%s`, identifier, address.String())
+	}
+
+	code = []byte(namedImportRe.ReplaceAllStringFunc(string(code), func(s string) string {
+		return rewrite(s, namedImportRe)
+	}))
+	code = []byte(legacyImportRe.ReplaceAllStringFunc(string(code), func(s string) string {
+		return rewrite(s, legacyImportRe)
+	}))
+
+	return code
+}
+
+// ResolveString is a convenience wrapper around Resolve for callers already holding a string.
+func (r *ImportResolver) ResolveString(code string) string {
+	return string(r.Resolve([]byte(code)))
+}
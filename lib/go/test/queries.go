@@ -0,0 +1,37 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	emulator "github.com/onflow/flow-emulator"
+)
+
+// PageOpts bounds an owner-indexed NFT query so enumerating a large collection doesn't blow up
+// script execution. Offset is the index of the first NFT to return, Limit the maximum number of
+// NFTs to return for this page.
+type PageOpts struct {
+	Limit  int
+	Offset int
+}
+
+// GetNFTsByOwnerPage runs an owner-indexed NFT query script against b and returns the resulting
+// cadence.Array's values alongside the offset a caller should pass back in to fetch the next
+// page. It's shared across the EPL, DSSCollection, and LockedNFT test packages: each project's
+// script returns its own NFTData shape (EnglishPremierLeague.NFTData, DSSCollection.NFTData,
+// LockedNFT.NFTData, ...), so decoding those values is left to the caller - only script
+// execution and pagination bookkeeping are common across projects.
+func GetNFTsByOwnerPage(
+	t *testing.T,
+	b *emulator.Blockchain,
+	script []byte,
+	args [][]byte,
+	page PageOpts,
+) ([]cadence.Value, int) {
+	t.Helper()
+
+	result := executeScriptAndCheck(t, b, script, args)
+	values := result.(cadence.Array).Values
+
+	return values, page.Offset + len(values)
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	emulator "github.com/onflow/flow-emulator"
+	"github.com/onflow/flow-go-sdk"
+
+	sharedtest "github.com/Flowtyio/studio-platform-smart-contracts/lib/go/test"
+)
+
+const (
+	GetNFTsByOwnerScriptPath = ScriptsRootPath + "/get_nfts_by_owner.cdc"
+)
+
+// NFTData is the Go-decoded view of LockedNFT.NFTData, the struct get_nfts_by_owner.cdc returns
+// one of per NFT.
+type NFTData struct {
+	ID          uint64
+	Name        string
+	Description string
+	Thumbnail   string
+}
+
+// NFTPage is a page of LockedNFT NFT data plus the offset a caller should pass back in to fetch
+// the next page.
+type NFTPage struct {
+	NFTs       []NFTData
+	NextOffset int
+}
+
+// parseNFTData decodes the LockedNFT.NFTData struct get_nfts_by_owner.cdc returns - id, then
+// Display metadata, in that order - into NFTData.
+func parseNFTData(value cadence.Value) NFTData {
+	fields := value.(cadence.Struct).Fields
+
+	return NFTData{
+		ID:          uint64(fields[0].(cadence.UInt64)),
+		Name:        string(fields[1].(cadence.String)),
+		Description: string(fields[2].(cadence.String)),
+		Thumbnail:   string(fields[3].(cadence.String)),
+	}
+}
+
+func getNFTsByOwnerScript(contracts Contracts) []byte {
+	return replaceAddresses(
+		readFile(GetNFTsByOwnerScriptPath),
+		contracts,
+	)
+}
+
+// getNFTsByOwner enumerates every NFT held by owner, the same way x/nft's gRPC surface exposes
+// NFTsOfOwner. Results are paginated via page so tests don't have to load an entire collection
+// into a single script execution. Script execution and pagination bookkeeping are shared with
+// the EPL and DSSCollection test packages through sharedtest; decoding into NFTData is
+// LockedNFT-specific, since each project's NFTData has its own shape.
+func getNFTsByOwner(
+	t *testing.T,
+	b *emulator.Blockchain,
+	contracts Contracts,
+	owner flow.Address,
+	page sharedtest.PageOpts,
+) NFTPage {
+	script := getNFTsByOwnerScript(contracts)
+	values, nextOffset := sharedtest.GetNFTsByOwnerPage(t, b, script, [][]byte{
+		jsoncdc.MustEncode(cadence.BytesToAddress(owner.Bytes())),
+		jsoncdc.MustEncode(cadence.NewInt(page.Offset)),
+		jsoncdc.MustEncode(cadence.NewInt(page.Limit)),
+	}, page)
+
+	nfts := make([]NFTData, 0, len(values))
+	for _, value := range values {
+		nfts = append(nfts, parseNFTData(value))
+	}
+	return NFTPage{NFTs: nfts, NextOffset: nextOffset}
+}